@@ -0,0 +1,92 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bingoohuang/statiq/fs"
+)
+
+var destFlag = flag.String("dest", "statiq", "directory the generated bundle package is written to")
+
+// statiq zips up -src and writes a Go source file under -dest that
+// registers the archive with fs.Register from an init func, so
+// importing that package for its side effect (as example/main.go does)
+// is enough to serve -src's contents through fs.New. It also registers
+// the archive in this process, so fs.New here sees the fresh bundle.
+func statiq() {
+	data, err := zipDir(*srcFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fs.Register(string(data))
+	if err := writeBundle(*destFlag, data); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("statiq: bundled", *srcFlag, "into", *destFlag)
+}
+
+// zipDir returns a zip archive of every regular file under root, with
+// archive paths relative to root.
+func zipDir(root string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBundle writes a generated "statiq.go" under dest that registers
+// data with fs.Register on import.
+func writeBundle(dest string, data []byte) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+
+	var src strings.Builder
+	fmt.Fprintln(&src, "// Code generated by statiq. DO NOT EDIT.")
+	fmt.Fprintln(&src)
+	fmt.Fprintln(&src, "package statiq")
+	fmt.Fprintln(&src)
+	fmt.Fprintln(&src, `import "github.com/bingoohuang/statiq/fs"`)
+	fmt.Fprintln(&src)
+	fmt.Fprintln(&src, "func init() {")
+	fmt.Fprintf(&src, "\tfs.Register(%q)\n", string(data))
+	fmt.Fprintln(&src, "}")
+
+	return ioutil.WriteFile(filepath.Join(dest, "statiq.go"), []byte(src.String()), 0644)
+}
@@ -0,0 +1,144 @@
+package fs
+
+import (
+	"html/template"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dirTemplate renders directory listings; override it with
+// SetDirTemplate to customize the markup.
+var dirTemplate = template.Must(template.New("dir").Parse(defaultDirHTML))
+
+// SetDirTemplate replaces the template used to render directory
+// listings. It must define the same fields as dirListingData.
+func SetDirTemplate(t *template.Template) {
+	dirTemplate = t
+}
+
+// DirLister is an http.Handler that renders an HTML directory index for
+// a directory in FS, with name/size/modtime columns, breadcrumb
+// navigation, and sorting via a ?sort=name|size|time query parameter.
+// FileServer falls back to it automatically for directories that have
+// no index.html; use it directly to build a custom handler.
+type DirLister struct {
+	FS http.FileSystem
+}
+
+func (dl DirLister) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/") {
+		localRedirect(w, r, path.Base(r.URL.Path)+"/")
+		return
+	}
+	name := path.Clean("/" + r.URL.Path)
+	if !renderDirListing(w, r, dl.FS, name) {
+		http.NotFound(w, r)
+	}
+}
+
+type dirEntry struct {
+	Name    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+}
+
+type breadcrumb struct {
+	Name string
+	Href string
+}
+
+type dirListingData struct {
+	Path        string
+	Breadcrumbs []breadcrumb
+	Entries     []dirEntry
+	Sort        string
+}
+
+// renderDirListing renders a directory index for name into w, unless an
+// index.html exists there, in which case it reports false and leaves w
+// untouched so the caller can serve that file instead.
+func renderDirListing(w http.ResponseWriter, r *http.Request, fsys http.FileSystem, name string) bool {
+	if idx, err := fsys.Open(path.Join(name, "index.html")); err == nil {
+		idx.Close()
+		return false
+	}
+
+	dir, err := fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	defer dir.Close()
+
+	infos, err := dir.Readdir(-1)
+	if err != nil {
+		return false
+	}
+
+	entries := make([]dirEntry, len(infos))
+	for i, fi := range infos {
+		entries[i] = dirEntry{Name: fi.Name(), IsDir: fi.IsDir(), Size: fi.Size(), ModTime: fi.ModTime()}
+	}
+
+	by := r.URL.Query().Get("sort")
+	sort.Slice(entries, func(i, j int) bool {
+		switch by {
+		case "size":
+			return entries[i].Size < entries[j].Size
+		case "time":
+			return entries[i].ModTime.Before(entries[j].ModTime)
+		default:
+			return entries[i].Name < entries[j].Name
+		}
+	})
+
+	data := dirListingData{
+		Path:        name,
+		Breadcrumbs: breadcrumbsFor(name),
+		Entries:     entries,
+		Sort:        by,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dirTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	return true
+}
+
+// breadcrumbsFor splits name into the chain of directories from root to
+// name, each with the href needed to navigate back to it.
+func breadcrumbsFor(name string) []breadcrumb {
+	crumbs := []breadcrumb{{Name: "/", Href: "/"}}
+	href := ""
+	for _, part := range strings.Split(strings.Trim(name, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		href += "/" + part
+		crumbs = append(crumbs, breadcrumb{Name: part, Href: href + "/"})
+	}
+	return crumbs
+}
+
+const defaultDirHTML = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Index of {{.Path}}</title></head>
+<body>
+<h1>
+{{range .Breadcrumbs}}<a href="{{.Href}}">{{.Name}}</a>/{{end}}
+</h1>
+<table>
+<tr><th><a href="?sort=name">Name</a></th><th><a href="?sort=size">Size</a></th><th><a href="?sort=time">Last modified</a></th></tr>
+{{range .Entries}}<tr>
+<td><a href="{{.Name}}{{if .IsDir}}/{{end}}">{{.Name}}{{if .IsDir}}/{{end}}</a></td>
+<td>{{if not .IsDir}}{{.Size}}{{end}}</td>
+<td>{{.ModTime.Format "2006-01-02 15:04:05"}}</td>
+</tr>{{end}}
+</table>
+</body>
+</html>
+`
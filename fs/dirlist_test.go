@@ -0,0 +1,58 @@
+package fs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFileServerRedirectsDirWithoutTrailingSlash(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{"sub/a.txt": "a", "sub/b.txt": "b"})
+	h := FileServer(fsys)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/sub", nil))
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMovedPermanently)
+	}
+	if got := rec.Header().Get("Location"); got != "sub/" {
+		t.Errorf("Location = %q, want %q", got, "sub/")
+	}
+}
+
+func TestFileServerDirListingSort(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{
+		"sub/b.txt": "bbbbb",
+		"sub/a.txt": "a",
+	})
+	h := FileServer(fsys)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/sub/?sort=size", nil))
+
+	body := rec.Body.String()
+	ai, bi := strings.Index(body, "a.txt"), strings.Index(body, "b.txt")
+	if ai < 0 || bi < 0 {
+		t.Fatalf("expected both entries to be listed, got:\n%s", body)
+	}
+	if ai > bi {
+		t.Errorf("sorted by size, a.txt (smaller) should come before b.txt, got:\n%s", body)
+	}
+}
+
+func TestDirListerServesListingDirectly(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{"sub/a.txt": "a"})
+	dl := DirLister{FS: fsys}
+
+	rec := httptest.NewRecorder()
+	dl.ServeHTTP(rec, httptest.NewRequest("GET", "/sub/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "a.txt") {
+		t.Errorf("expected listing to contain a.txt, got:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,172 @@
+package fs
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// CompressedFile is implemented by files that carry precomputed
+// compressed variants alongside their raw bytes, as File does.
+type CompressedFile interface {
+	GzipData() ([]byte, bool)
+	BrotliData() ([]byte, bool)
+}
+
+// Etager is implemented by files that carry a precomputed ETag, as File
+// does.
+type Etager interface {
+	ETag() string
+}
+
+// lastModifier is implemented by files that can report a fallback
+// modification time for entries the zip didn't record one for, as File
+// does.
+type lastModifier interface {
+	LastModified() time.Time
+}
+
+// FileServer returns an http.Handler that serves files from fsys like
+// http.FileServer, but additionally:
+//   - negotiates Content-Encoding against a file's precomputed
+//     gzip/brotli variants when it implements CompressedFile, avoiding
+//     per-request compression;
+//   - emits ETag and Cache-Control, and honors If-None-Match and
+//     If-Modified-Since, when the file implements Etager.
+func FileServer(fsys http.FileSystem) http.Handler {
+	plain := http.FileServer(fsys)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveFile(w, r, fsys) {
+			return
+		}
+		plain.ServeHTTP(w, r)
+	})
+}
+
+// serveFile serves name from fsys, honoring ETag/compression metadata
+// when the file provides it. It reports whether it served the request,
+// so the caller can fall back to plain http.FileServer behavior
+// otherwise (misses, errors, and directories that could not be listed).
+func serveFile(w http.ResponseWriter, r *http.Request, fsys http.FileSystem) bool {
+	name := path.Clean("/" + r.URL.Path)
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	if fi.IsDir() {
+		// Match http.FileServer: a directory request without a trailing
+		// slash must be redirected there first, since the listing's
+		// relative links are only correct resolved against name+"/".
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			localRedirect(w, r, path.Base(r.URL.Path)+"/")
+			return true
+		}
+
+		// A directory with an index.html is served through the same
+		// ETag/compression-aware path as any other file, rather than
+		// falling back to renderDirListing (which itself declines in
+		// this case) or plain http.FileServer.
+		indexName := path.Join(name, "index.html")
+		idx, err := fsys.Open(indexName)
+		if err != nil {
+			return renderDirListing(w, r, fsys, name)
+		}
+		defer idx.Close()
+		idxInfo, err := idx.Stat()
+		if err != nil {
+			return renderDirListing(w, r, fsys, name)
+		}
+		return serveOpenFile(w, r, indexName, idxInfo, idx)
+	}
+
+	return serveOpenFile(w, r, name, fi, f)
+}
+
+// serveOpenFile serves the already-opened, already-stat'd regular file f
+// as name, honoring ETag/compression metadata when f provides it.
+func serveOpenFile(w http.ResponseWriter, r *http.Request, name string, fi os.FileInfo, f http.File) bool {
+	modtime := fi.ModTime()
+	if lm, ok := f.(lastModifier); ok {
+		modtime = lm.LastModified()
+	}
+
+	if et, ok := f.(Etager); ok {
+		etag := et.ETag()
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	if cf, ok := f.(CompressedFile); ok {
+		accept := r.Header.Get("Accept-Encoding")
+		w.Header().Add("Vary", "Accept-Encoding")
+		if strings.Contains(accept, "br") {
+			if data, ok := cf.BrotliData(); ok {
+				serveBytes(w, r, name, modtime, "br", data)
+				return true
+			}
+		}
+		if strings.Contains(accept, "gzip") {
+			if data, ok := cf.GzipData(); ok {
+				serveBytes(w, r, name, modtime, "gzip", data)
+				return true
+			}
+		}
+	}
+
+	if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+		w.Header().Set("Content-Type", ctype)
+	}
+	http.ServeContent(w, r, name, modtime, f)
+	return true
+}
+
+func serveBytes(w http.ResponseWriter, r *http.Request, name string, modtime time.Time, encoding string, data []byte) {
+	if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+		w.Header().Set("Content-Type", ctype)
+	}
+	w.Header().Set("Content-Encoding", encoding)
+	http.ServeContent(w, r, name, modtime, bytes.NewReader(data))
+}
+
+// localRedirect sends newPath as a relative redirect, preserving the
+// request's query string, the same way net/http's FileServer redirects
+// a directory request to add a trailing slash.
+func localRedirect(w http.ResponseWriter, r *http.Request, newPath string) {
+	if q := r.URL.RawQuery; q != "" {
+		newPath += "?" + q
+	}
+	w.Header().Set("Location", newPath)
+	w.WriteHeader(http.StatusMovedPermanently)
+}
+
+// etagMatches reports whether etag appears in the comma-separated
+// If-None-Match header value, per RFC 7232.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if ifNoneMatch == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimSpace(tag) == etag {
+			return true
+		}
+	}
+	return false
+}
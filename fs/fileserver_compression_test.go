@@ -0,0 +1,40 @@
+package fs
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFileServerCompressionNegotiation(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{"a.txt": "hello world"})
+	h := FileServer(fsys)
+
+	cases := []struct {
+		name           string
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{"prefers brotli", "gzip, br", "br"},
+		{"falls back to gzip", "gzip", "gzip"},
+		{"no match leaves plain", "identity", ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/a.txt", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Errorf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+			if rec.Body.Len() == 0 {
+				t.Error("expected a non-empty response body")
+			}
+		})
+	}
+}
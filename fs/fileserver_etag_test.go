@@ -0,0 +1,58 @@
+package fs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFileServerETag(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{"a.txt": "hello world"})
+	h := FileServer(fsys)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/a.txt", nil))
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag header")
+	}
+	if got := rec.Header().Get("Cache-Control"); got == "" {
+		t.Error("expected a Cache-Control header")
+	}
+
+	t.Run("matching If-None-Match returns 304", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/a.txt", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusNotModified)
+		}
+	})
+
+	t.Run("mismatched If-None-Match returns 200", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/a.txt", nil)
+		req.Header.Set("If-None-Match", `"not-the-etag"`)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+}
+
+func TestFileServerLastModifiedFallsBackToBuildTime(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{"a.txt": "hello world"})
+	h := FileServer(fsys)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/a.txt", nil))
+
+	want := fsys.buildTime.UTC().Format(http.TimeFormat)
+	if got := rec.Header().Get("Last-Modified"); got != want {
+		t.Errorf("Last-Modified = %q, want the StaticalFS build time %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package fs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFileServerServesDirIndexThroughRichPath(t *testing.T) {
+	fsys := newTestFS(t, map[string]string{"index.html": "<html>hi</html>"})
+	h := FileServer(fsys)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected index.html served for / to carry an ETag, not fall back to plain http.FileServer")
+	}
+
+	t.Run("honors If-None-Match", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusNotModified)
+		}
+	})
+
+	t.Run("negotiates compression", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("Content-Encoding = %q, want gzip", got)
+		}
+	})
+}
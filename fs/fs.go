@@ -37,11 +37,46 @@ type File struct {
 	os.FileInfo
 	Data []byte
 	Fs   *StaticalFS
+
+	gzipData   []byte
+	brotliData []byte
+	etag       string
+}
+
+// GzipData returns the file's precomputed gzip-compressed bytes, and
+// whether a compressed variant is available at all.
+func (f File) GzipData() ([]byte, bool) {
+	return f.gzipData, f.gzipData != nil
+}
+
+// BrotliData returns the file's precomputed brotli-compressed bytes, and
+// whether a compressed variant is available at all.
+func (f File) BrotliData() ([]byte, bool) {
+	return f.brotliData, f.brotliData != nil
+}
+
+// ETag returns the file's precomputed ETag, satisfying Etager.
+func (f File) ETag() string {
+	return f.etag
+}
+
+// LastModified returns the file's modification time as recorded in the
+// zip entry, or the time its StaticalFS was built if the entry didn't
+// record one. An entry written without a modification time reads back
+// from the zip as the zero MS-DOS date (1979-11-30), not as a zero
+// time.Time, so any time before 1980 counts as unrecorded.
+func (f File) LastModified() time.Time {
+	if mt := f.FileInfo.ModTime(); !mt.IsZero() && !mt.Before(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		return mt
+	}
+	return f.Fs.buildTime
 }
 
 type StaticalFS struct {
 	Files map[string]File
 	Dirs  map[string][]string
+
+	buildTime time.Time
 }
 
 // Register registers zip contents data, later used to initialize
@@ -56,20 +91,78 @@ func New() (*StaticalFS, error) {
 	if ZipData == "" {
 		return nil, errors.New("statical/fs: no zip data registered")
 	}
-	zipReader, err := zip.NewReader(strings.NewReader(ZipData), int64(len(ZipData)))
+	return NewFromSource(registeredSource{})
+}
+
+// NewFromSource creates a new file system backed by the given Source. It
+// unzips all files from the Source's archive and stores them in an
+// in-memory map, same as New does for the registered zip data.
+func NewFromSource(src Source) (*StaticalFS, error) {
+	r, size, err := src.Open()
 	if err != nil {
 		return nil, err
 	}
+	if c, ok := r.(io.Closer); ok {
+		// fileSource hands back an *os.File; close it once the archive
+		// is fully read into memory below so NewFromZip doesn't leak a
+		// descriptor per call.
+		defer c.Close()
+	}
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return newFromZipReader(zipReader)
+}
+
+// NewFromDir creates a new file system backed by a live directory on
+// disk. Unlike New, files are read directly from dir and are not cached
+// in memory, so edits made on disk are picked up on the next request -
+// handy for running in "dev mode" against the same serving code used
+// for the embedded "prod mode" bundle.
+func NewFromDir(dir string) (http.FileSystem, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("statical/fs: opening dir %q: %s", dir, err)
+	}
+	return http.Dir(dir), nil
+}
+
+// NewFromZip creates a new file system from a zip file at the given path
+// on disk. The file is opened lazily via zip.NewReader, once the archive
+// is actually needed.
+func NewFromZip(path string) (*StaticalFS, error) {
+	return NewFromSource(fileSource{path: path})
+}
+
+// NewFromURL creates a new file system from a zip archive fetched over
+// HTTP. The archive is read with ranged requests through an io.ReaderAt
+// rather than one full download, but every entry is still unzipped into
+// memory before NewFromURL returns, so all of the archive's file data is
+// fetched up front.
+func NewFromURL(url string) (*StaticalFS, error) {
+	return NewFromSource(httpSource{url: url})
+}
+
+func newFromZipReader(zipReader *zip.Reader) (*StaticalFS, error) {
 	files := make(map[string]File, len(zipReader.File))
 	dirs := make(map[string][]string)
-	fs := &StaticalFS{Files: files, Dirs: dirs}
+	fs := &StaticalFS{Files: files, Dirs: dirs, buildTime: time.Now()}
 	for _, zipFile := range zipReader.File {
 		fi := zipFile.FileInfo()
 		f := File{FileInfo: fi, Fs: fs}
+		var err error
 		f.Data, err = unzip(zipFile)
 		if err != nil {
 			return nil, fmt.Errorf("statical/fs: error unzipping file %q: %s", zipFile.Name, err)
 		}
+		if !fi.IsDir() {
+			// Precompute compressed variants and the ETag once here,
+			// rather than per-request, so FileServer can serve them
+			// straight out of memory.
+			f.gzipData, _ = gzipBytes(f.Data)
+			f.brotliData, _ = brotliBytes(f.Data)
+			f.etag = etagFor(f.Data)
+		}
 		files["/"+zipFile.Name] = f
 	}
 	for fn := range files {
@@ -0,0 +1,124 @@
+package fs
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// buildZip returns the bytes of a zip archive containing files, keyed by
+// archive-relative path.
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newTestFS builds a StaticalFS directly from files, bypassing any
+// particular Source.
+func newTestFS(t *testing.T, files map[string]string) *StaticalFS {
+	t.Helper()
+	data := buildZip(t, files)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := newFromZipReader(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fsys
+}
+
+func assertFileContent(t *testing.T, fsys http.FileSystem, name, want string) {
+	t.Helper()
+	f, err := fsys.Open(name)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", name, err)
+	}
+	defer f.Close()
+	got, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("%s: got %q, want %q", name, got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	data := buildZip(t, map[string]string{"hello.txt": "hello world"})
+	Register(string(data))
+	t.Cleanup(func() { Register("") })
+
+	fsys, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFileContent(t, fsys, "/hello.txt", "hello world")
+}
+
+func TestNewFromZip(t *testing.T) {
+	data := buildZip(t, map[string]string{"hello.txt": "hello world"})
+	p := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := ioutil.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys, err := NewFromZip(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFileContent(t, fsys, "/hello.txt", "hello world")
+}
+
+func TestNewFromURL(t *testing.T) {
+	data := buildZip(t, map[string]string{"hello.txt": "hello world"})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "bundle.zip", time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	fsys, err := NewFromURL(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFileContent(t, fsys, "/hello.txt", "hello world")
+}
+
+func TestNewFromDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello world"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys, err := NewFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFileContent(t, fsys, "/hello.txt", "hello world")
+}
+
+func TestNewFromDirMissing(t *testing.T) {
+	if _, err := NewFromDir(filepath.Join(t.TempDir(), "does-not-exist")); err == nil {
+		t.Fatal("expected an error for a missing directory")
+	}
+}
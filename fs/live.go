@@ -0,0 +1,31 @@
+package fs
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// LiveFS wraps a StaticalFS behind an atomic pointer so its contents can
+// be swapped out for a freshly rebuilt one without restarting the
+// server or invalidating any handler holding a reference to the LiveFS.
+type LiveFS struct {
+	current atomic.Value // *StaticalFS
+}
+
+// NewLiveFS creates a LiveFS initially backed by fs.
+func NewLiveFS(fs *StaticalFS) *LiveFS {
+	lfs := &LiveFS{}
+	lfs.current.Store(fs)
+	return lfs
+}
+
+// Swap atomically replaces the contents served by lfs.
+func (lfs *LiveFS) Swap(fs *StaticalFS) {
+	lfs.current.Store(fs)
+}
+
+// Open implements http.FileSystem against whichever StaticalFS is
+// currently active.
+func (lfs *LiveFS) Open(name string) (http.File, error) {
+	return lfs.current.Load().(*StaticalFS).Open(name)
+}
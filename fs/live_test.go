@@ -0,0 +1,28 @@
+package fs
+
+import "testing"
+
+func TestLiveFSServesInitialContent(t *testing.T) {
+	initial := newTestFS(t, map[string]string{"a.txt": "v1"})
+	lfs := NewLiveFS(initial)
+
+	assertFileContent(t, lfs, "/a.txt", "v1")
+}
+
+func TestLiveFSSwap(t *testing.T) {
+	initial := newTestFS(t, map[string]string{"a.txt": "v1"})
+	next := newTestFS(t, map[string]string{"a.txt": "v2"})
+	lfs := NewLiveFS(initial)
+
+	lfs.Swap(next)
+
+	assertFileContent(t, lfs, "/a.txt", "v2")
+}
+
+func TestLiveFSOpenMissingFile(t *testing.T) {
+	lfs := NewLiveFS(newTestFS(t, nil))
+
+	if _, err := lfs.Open("/nope.txt"); err == nil {
+		t.Fatal("expected an error opening a file that doesn't exist")
+	}
+}
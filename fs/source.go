@@ -0,0 +1,104 @@
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Source supplies the zip archive bytes that back a StaticalFS, so New
+// and friends can build the file system from something other than the
+// zip data registered via Register.
+type Source interface {
+	// Open returns a ReaderAt positioned over the full zip archive,
+	// along with its total size.
+	Open() (io.ReaderAt, int64, error)
+}
+
+// registeredSource reads the zip data passed to Register.
+type registeredSource struct{}
+
+func (registeredSource) Open() (io.ReaderAt, int64, error) {
+	if ZipData == "" {
+		return nil, 0, errors.New("statical/fs: no zip data registered")
+	}
+	return strings.NewReader(ZipData), int64(len(ZipData)), nil
+}
+
+// fileSource reads a zip archive from a local path, opening it lazily so
+// the file handle isn't held until the archive is actually needed.
+type fileSource struct {
+	path string
+}
+
+func (s fileSource) Open() (io.ReaderAt, int64, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("statical/fs: opening zip %q: %s", s.path, err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, fmt.Errorf("statical/fs: stat zip %q: %s", s.path, err)
+	}
+	return f, fi.Size(), nil
+}
+
+// httpSource fetches a zip archive over HTTP, reading it with ranged
+// requests through an io.ReaderAt instead of one full download. Each
+// ReadAt is its own request; nothing is cached between calls.
+type httpSource struct {
+	url string
+}
+
+func (s httpSource) Open() (io.ReaderAt, int64, error) {
+	resp, err := http.Head(s.url)
+	if err != nil {
+		return nil, 0, fmt.Errorf("statical/fs: HEAD %q: %s", s.url, err)
+	}
+	resp.Body.Close()
+	if resp.ContentLength <= 0 {
+		return nil, 0, fmt.Errorf("statical/fs: %q did not report a Content-Length", s.url)
+	}
+	return &httpReaderAt{url: s.url}, resp.ContentLength, nil
+}
+
+// httpReaderAt implements io.ReaderAt over an HTTP URL using a Range
+// request per ReadAt call.
+type httpReaderAt struct {
+	url string
+}
+
+func (r *httpReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+int64(len(p))-1))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		// The server honored the Range request; resp.Body already
+		// starts at off.
+		return io.ReadFull(resp.Body, p)
+	case http.StatusOK:
+		// The server ignored the Range header and sent the whole body
+		// from byte 0. Discard the leading off bytes ourselves instead
+		// of silently reading the wrong slice of the archive.
+		if _, err := io.CopyN(io.Discard, resp.Body, off); err != nil {
+			return 0, fmt.Errorf("statical/fs: GET %q: server ignored Range and body is shorter than offset %d: %s", r.url, off, err)
+		}
+		return io.ReadFull(resp.Body, p)
+	default:
+		return 0, fmt.Errorf("statical/fs: GET %q: unexpected status %s", r.url, resp.Status)
+	}
+}
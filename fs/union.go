@@ -0,0 +1,127 @@
+package fs
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"sort"
+)
+
+// OverlayFS layers multiple file systems so that later ones shadow
+// earlier ones on conflicting paths, merging directory listings across
+// all of them. This lets a project serve a base bundle plus a
+// site-specific override bundle without rebuilding a single zip.
+type OverlayFS struct {
+	sources []http.FileSystem
+}
+
+// Union builds an OverlayFS from sources, given lowest to highest
+// priority: sources[i+1] shadows sources[i] on conflicting paths.
+func Union(sources ...http.FileSystem) *OverlayFS {
+	return &OverlayFS{sources: sources}
+}
+
+// Sources returns the file systems making up o, in the same
+// lowest-to-highest priority order passed to Union.
+func (o *OverlayFS) Sources() []http.FileSystem {
+	return o.sources
+}
+
+// Open returns the file at name from the highest-priority source that
+// has it, fully shadowing lower-priority sources: the type (file or
+// directory) the highest-priority source answers with wins outright.
+// If that answer is a directory, lower-priority sources are merged into
+// it as long as they also have a directory at name; a lower-priority
+// plain file does not un-shadow an already-found directory.
+func (o *OverlayFS) Open(name string) (http.File, error) {
+	var dirs []http.File
+	for i := len(o.sources) - 1; i >= 0; i-- {
+		f, err := o.sources[i].Open(name)
+		if err != nil {
+			continue
+		}
+		fi, err := f.Stat()
+		if err != nil {
+			f.Close()
+			continue
+		}
+		if !fi.IsDir() {
+			if len(dirs) == 0 {
+				return f, nil
+			}
+			f.Close()
+			continue
+		}
+		dirs = append(dirs, f)
+	}
+	if len(dirs) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return mergeDirs(name, dirs)
+}
+
+// mergeDirs merges the listings of dirs, ordered highest to lowest
+// priority, into a single directory file and closes each of them.
+func mergeDirs(name string, dirs []http.File) (http.File, error) {
+	seen := make(map[string]os.FileInfo)
+	var order []string
+	for _, d := range dirs {
+		infos, err := d.Readdir(-1)
+		d.Close()
+		if err != nil {
+			continue
+		}
+		for _, fi := range infos {
+			if _, ok := seen[fi.Name()]; ok {
+				continue
+			}
+			seen[fi.Name()] = fi
+			order = append(order, fi.Name())
+		}
+	}
+	sort.Strings(order)
+
+	fis := make([]os.FileInfo, len(order))
+	for i, n := range order {
+		fis[i] = seen[n]
+	}
+	return &overlayDir{name: name, fis: fis}, nil
+}
+
+// overlayDir is the http.File returned for every directory opened
+// through an OverlayFS, merged from each source that has a directory at
+// that path (possibly just one).
+type overlayDir struct {
+	name string
+	fis  []os.FileInfo
+	idx  int
+}
+
+func (d *overlayDir) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (d *overlayDir) Seek(offset int64, whence int) (int64, error) {
+	return 0, nil
+}
+
+func (d *overlayDir) Close() error {
+	return nil
+}
+
+func (d *overlayDir) Stat() (os.FileInfo, error) {
+	return dirInfo{d.name}, nil
+}
+
+func (d *overlayDir) Readdir(count int) ([]os.FileInfo, error) {
+	start := d.idx
+	if start >= len(d.fis) && count > 0 {
+		return nil, io.EOF
+	}
+	end := len(d.fis)
+	if count > 0 && start+count < end {
+		end = start + count
+	}
+	d.idx = end
+	return d.fis[start:end], nil
+}
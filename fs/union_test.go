@@ -0,0 +1,85 @@
+package fs
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestUnionFileShadowsFile(t *testing.T) {
+	base := newTestFS(t, map[string]string{"a.txt": "base"})
+	override := newTestFS(t, map[string]string{"a.txt": "override"})
+
+	assertFileContent(t, Union(base, override), "/a.txt", "override")
+}
+
+func TestUnionDirShadowsFile(t *testing.T) {
+	base := newTestFS(t, map[string]string{"conflict": "i-am-a-file"})
+	override := newTestFS(t, map[string]string{"conflict/y.txt": "y"})
+
+	f, err := Union(base, override).Open("/conflict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Fatal("expected the higher-priority override's directory to shadow the base's file")
+	}
+}
+
+func TestUnionFileDoesNotUnshadowDir(t *testing.T) {
+	// override (highest priority) has a directory at /conflict; a lower
+	// priority source having a plain file there must not win.
+	base := newTestFS(t, map[string]string{"conflict": "i-am-a-file"})
+	override := newTestFS(t, map[string]string{"conflict/y.txt": "y"})
+
+	f, err := Union(base, override).Open("/conflict/y.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	got, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "y" {
+		t.Errorf("got %q, want %q", got, "y")
+	}
+}
+
+func TestUnionMergesDirListings(t *testing.T) {
+	base := newTestFS(t, map[string]string{"dir/a.txt": "a"})
+	override := newTestFS(t, map[string]string{"dir/b.txt": "b"})
+
+	f, err := Union(base, override).Open("/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	infos, err := f.Readdir(-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool, len(infos))
+	for _, fi := range infos {
+		names[fi.Name()] = true
+	}
+	if !names["a.txt"] || !names["b.txt"] {
+		t.Errorf("expected merged listing to contain both a.txt and b.txt, got %v", names)
+	}
+}
+
+func TestUnionSources(t *testing.T) {
+	base := newTestFS(t, nil)
+	override := newTestFS(t, nil)
+
+	got := Union(base, override).Sources()
+	if len(got) != 2 {
+		t.Fatalf("Sources() len = %d, want 2", len(got))
+	}
+}
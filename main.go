@@ -0,0 +1,25 @@
+package main
+
+import "flag"
+
+var srcFlag = flag.String("src", "./public", "path to the static source directory to bundle")
+
+// main is the statiq entry point. Plain `statiq` rebuilds the bundle
+// once; `-watch` rebuilds it again on every change to -src; `-watch
+// -serve :8080` additionally serves the bundle on that address in dev
+// mode, hot-swapping it and pushing a live-reload event to the browser
+// on every rebuild.
+func main() {
+	flag.Parse()
+
+	statiq()
+
+	if *serveFlag != "" {
+		serve(*srcFlag, *serveFlag)
+		return
+	}
+
+	if *watchFlag {
+		watchSrc(*srcFlag, nil)
+	}
+}
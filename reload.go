@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsConn is the subset of *websocket.Conn that reloadHub needs to fan a
+// reload message out to. Broken out as an interface so Broadcast's
+// fan-out/error-handling logic can be unit tested with a fake, without a
+// real socket.
+type wsConn interface {
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// reloadHub tracks browsers connected for live reload and broadcasts a
+// reload message to all of them whenever the bundle is rebuilt.
+type reloadHub struct {
+	upgrader websocket.Upgrader
+
+	mu      sync.Mutex
+	clients map[wsConn]bool
+}
+
+func newReloadHub() *reloadHub {
+	return &reloadHub{
+		upgrader: websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+		clients:  make(map[wsConn]bool),
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket and registers the
+// connection so Broadcast can reach it later.
+func (h *reloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("reload: upgrade failed:", err)
+		return
+	}
+
+	h.mu.Lock()
+	h.clients[conn] = true
+	h.mu.Unlock()
+
+	// Drain and discard messages until the browser disconnects; this is
+	// only a one-way notification channel.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			h.mu.Lock()
+			delete(h.clients, conn)
+			h.mu.Unlock()
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Broadcast tells every connected browser to reload.
+func (h *reloadHub) Broadcast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.clients {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte("reload")); err != nil {
+			conn.Close()
+			delete(h.clients, conn)
+		}
+	}
+}
+
+// reloadScript is injected into served text/html responses; it opens a
+// WebSocket back to the reloadHub and reloads the page on any message.
+const reloadScript = `<script>(function() {
+	var proto = location.protocol === "https:" ? "wss://" : "ws://";
+	var sock = new WebSocket(proto + location.host + "/__statiq_reload");
+	sock.onmessage = function() { location.reload(); };
+})();</script>`
+
+// withLiveReload wraps next so any text/html response it produces gets
+// reloadScript injected just before the closing </body> tag.
+func withLiveReload(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &htmlInjectingWriter{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		rec.flush()
+	})
+}
+
+// htmlInjectingWriter buffers text/html responses so reloadScript can be
+// inserted before they're written out; non-HTML responses pass through
+// untouched.
+type htmlInjectingWriter struct {
+	http.ResponseWriter
+
+	buf         bytes.Buffer
+	isHTML      bool
+	wroteHeader bool
+}
+
+func (w *htmlInjectingWriter) WriteHeader(status int) {
+	w.isHTML = strings.HasPrefix(w.Header().Get("Content-Type"), "text/html")
+	if w.isHTML {
+		w.Header().Del("Content-Length")
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *htmlInjectingWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.isHTML {
+		return w.buf.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *htmlInjectingWriter) flush() {
+	if !w.isHTML {
+		return
+	}
+	body := w.buf.Bytes()
+	idx := bytes.LastIndex(body, []byte("</body>"))
+	if idx < 0 {
+		w.ResponseWriter.Write(body)
+		return
+	}
+	var out bytes.Buffer
+	out.Write(body[:idx])
+	out.WriteString(reloadScript)
+	out.Write(body[idx:])
+	w.ResponseWriter.Write(out.Bytes())
+}
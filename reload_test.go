@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTMLInjectingWriterInjectsBeforeClosingBody(t *testing.T) {
+	h := withLiveReload(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body>hi</body></html>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	body := rec.Body.String()
+	if !strings.Contains(body, reloadScript) {
+		t.Fatalf("expected reloadScript to be injected, got:\n%s", body)
+	}
+	if idx := strings.Index(body, reloadScript); idx > strings.Index(body, "</body>") {
+		t.Errorf("expected reloadScript before </body>, got:\n%s", body)
+	}
+}
+
+func TestHTMLInjectingWriterLeavesNonHTMLAlone(t *testing.T) {
+	h := withLiveReload(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("hello"))
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if got := rec.Body.String(); got != "hello" {
+		t.Errorf("body = %q, want unmodified %q", got, "hello")
+	}
+}
+
+func TestHTMLInjectingWriterWithoutClosingBody(t *testing.T) {
+	h := withLiveReload(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>no body tag</html>"))
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if got := rec.Body.String(); got != "<html>no body tag</html>" {
+		t.Errorf("body = %q, want passthrough unmodified", got)
+	}
+}
+
+// fakeConn is a wsConn that records writes and can be made to fail, so
+// reloadHub.Broadcast's fan-out and dead-connection-removal logic can be
+// tested without a real socket.
+type fakeConn struct {
+	failWrite bool
+	closed    bool
+	wrote     int
+}
+
+func (c *fakeConn) WriteMessage(messageType int, data []byte) error {
+	if c.failWrite {
+		return errors.New("write failed")
+	}
+	c.wrote++
+	return nil
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestReloadHubBroadcastNotifiesAllClients(t *testing.T) {
+	hub := newReloadHub()
+	a, b := &fakeConn{}, &fakeConn{}
+	hub.clients[a] = true
+	hub.clients[b] = true
+
+	hub.Broadcast()
+
+	if a.wrote != 1 || b.wrote != 1 {
+		t.Errorf("expected both clients to receive one message, got a=%d b=%d", a.wrote, b.wrote)
+	}
+}
+
+func TestReloadHubBroadcastDropsFailingClients(t *testing.T) {
+	hub := newReloadHub()
+	ok, broken := &fakeConn{}, &fakeConn{failWrite: true}
+	hub.clients[ok] = true
+	hub.clients[broken] = true
+
+	hub.Broadcast()
+
+	if !broken.closed {
+		t.Error("expected the failing connection to be closed")
+	}
+	if _, stillThere := hub.clients[broken]; stillThere {
+		t.Error("expected the failing connection to be removed from clients")
+	}
+	if _, stillThere := hub.clients[ok]; !stillThere {
+		t.Error("expected the healthy connection to remain registered")
+	}
+}
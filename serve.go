@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/bingoohuang/statiq/fs"
+)
+
+var (
+	watchFlag = flag.Bool("watch", false, "watch -src and rebuild the bundle on change")
+	serveFlag = flag.String("serve", "", "address to serve the bundle on in dev mode, e.g. :8080")
+)
+
+// liveReloader hot-swaps the statiq bundle served by fs whenever
+// watchSrc rebuilds it, then tells hub to push a reload to any
+// connected browser.
+type liveReloader struct {
+	fs  *fs.LiveFS
+	hub *reloadHub
+}
+
+func (r *liveReloader) rebuilt() {
+	next, err := fs.New()
+	if err != nil {
+		log.Println("reload: rebuild failed:", err)
+		return
+	}
+	r.fs.Swap(next)
+	r.hub.Broadcast()
+}
+
+// serve runs statiq in dev-server mode: it serves the current bundle on
+// addr, injecting a live-reload script into HTML responses, while
+// watchSrc rebuilds the bundle in the background and hot-swaps it in on
+// every change to srcPath.
+func serve(srcPath, addr string) {
+	initial, err := fs.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+	liveFS := fs.NewLiveFS(initial)
+	hub := newReloadHub()
+
+	go watchSrc(srcPath, &liveReloader{fs: liveFS, hub: hub})
+
+	mux := http.NewServeMux()
+	mux.Handle("/__statiq_reload", hub)
+	mux.Handle("/", withLiveReload(http.FileServer(liveFS)))
+
+	fmt.Println("serving", addr, "in dev mode, watching", srcPath)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
@@ -7,7 +7,11 @@ import (
 	"github.com/fsnotify/fsnotify"
 )
 
-func watchSrc(srcPath string) {
+// watchSrc watches srcPath for changes and rebuilds the statiq bundle on
+// every write or remove. When reload is non-nil, each rebuild also
+// hot-swaps the served contents and notifies connected browsers, turning
+// this into the engine behind "statiq -watch -serve".
+func watchSrc(srcPath string, reload *liveReloader) {
 	// https://github.com/elliotforbes/go-webassembly-framework/blob/master/internal/commands/start.go
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -30,6 +34,9 @@ func watchSrc(srcPath string) {
 			case e.Op&fsnotify.Write == fsnotify.Write, e.Op&fsnotify.Remove == fsnotify.Remove:
 				fmt.Println("rebuild")
 				statiq()
+				if reload != nil {
+					reload.rebuilt()
+				}
 			default:
 				fmt.Println("ignored")
 			}